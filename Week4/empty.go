@@ -0,0 +1,57 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// quietEngine wraps a consensus engine and holds back sealing of blocks that
+// carry no transactions.
+type quietEngine struct {
+	Engine
+	idle time.Duration // Wait before sealing an empty block, zero to never seal one
+}
+
+// SuppressEmptyBlocks wraps engine so that blocks without transactions are not
+// sealed straight away. With a zero idle timeout empty blocks are refused with
+// ErrEmptyBlock; otherwise they are sealed only if the sealing request is not
+// abandoned within the timeout, keeping development chains quiet when nothing
+// is happening. Negative timeouts are treated as zero.
+//
+// The wrapper can be stacked on either side of GuardDoubleSign: the guard only
+// journals blocks the engine beneath it actually sealed, so refused or delayed
+// empty blocks never lock their height.
+func SuppressEmptyBlocks(engine Engine, idle time.Duration) Engine {
+	if idle < 0 {
+		idle = 0
+	}
+	return &quietEngine{Engine: engine, idle: idle}
+}
+
+// Seal implements consensus.Engine, delaying or refusing empty blocks before
+// handing them to the wrapped engine.
+func (e *quietEngine) Seal(chain ChainHeaderReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	if len(block.Transactions()) > 0 {
+		return e.Engine.Seal(chain, block, results, stop)
+	}
+	if e.idle == 0 {
+		return ErrEmptyBlock
+	}
+	go func() {
+		select {
+		case <-stop:
+			return
+		case <-time.After(e.idle):
+		}
+		if err := e.Engine.Seal(chain, block, results, stop); err != nil {
+			log.Warn("Failed to seal empty block", "number", block.Number(), "err", err)
+		}
+	}()
+	return nil
+}
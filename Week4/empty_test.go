@@ -0,0 +1,84 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that empty blocks are refused outright with a zero or negative idle
+// timeout, without reaching the wrapped engine.
+func TestSuppressEmptyBlocksRefuse(t *testing.T) {
+	for _, idle := range []time.Duration{0, -time.Second} {
+		inner := new(testSealer)
+		engine := SuppressEmptyBlocks(inner, idle)
+
+		if err := sealBlock(engine, testBlock(1, 1)); err != ErrEmptyBlock {
+			t.Errorf("idle %v: error mismatch: have %v, want %v", idle, err, ErrEmptyBlock)
+		}
+		if calls := atomic.LoadInt32(&inner.calls); calls != 0 {
+			t.Errorf("idle %v: wrapped engine sealed %d times", idle, calls)
+		}
+	}
+}
+
+// Tests that blocks with transactions are sealed straight away.
+func TestSuppressEmptyBlocksPassThrough(t *testing.T) {
+	engine := SuppressEmptyBlocks(&testSealer{sync: true}, time.Hour)
+
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	block := testBlock(1, 1).WithBody([]*types.Transaction{tx}, nil)
+
+	start := time.Now()
+	if err := sealBlock(engine, block); err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("block with transactions delayed by %v", elapsed)
+	}
+}
+
+// Tests that empty blocks are sealed only after the idle timeout.
+func TestSuppressEmptyBlocksIdle(t *testing.T) {
+	idle := 50 * time.Millisecond
+	engine := SuppressEmptyBlocks(new(testSealer), idle)
+
+	start := time.Now()
+	if err := sealBlock(engine, testBlock(1, 1)); err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < idle {
+		t.Fatalf("empty block sealed after %v, want at least %v", elapsed, idle)
+	}
+}
+
+// Tests that stopping sealing during the idle wait means nothing is sealed.
+func TestSuppressEmptyBlocksStopped(t *testing.T) {
+	inner := new(testSealer)
+	engine := SuppressEmptyBlocks(inner, 50*time.Millisecond)
+
+	results := make(chan *types.Block, 1)
+	stop := make(chan struct{})
+	if err := engine.Seal(nil, testBlock(1, 1), results, stop); err != nil {
+		t.Fatalf("failed to start sealing: %v", err)
+	}
+	close(stop)
+	time.Sleep(150 * time.Millisecond)
+
+	if calls := atomic.LoadInt32(&inner.calls); calls != 0 {
+		t.Fatalf("wrapped engine sealed %d times after stop", calls)
+	}
+	select {
+	case block := <-results:
+		t.Fatalf("stopped seal delivered block %v", block.Hash())
+	default:
+	}
+}
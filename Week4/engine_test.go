@@ -0,0 +1,85 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// testSealer is a consensus engine that seals blocks by passing them through
+// unchanged, optionally failing or waiting to be released first. Only the
+// methods used by the engine wrappers are implemented.
+type testSealer struct {
+	Engine
+	err     error         // Error returned from Seal
+	release chan struct{} // Closed to let sealing complete, nil to seal at once
+	sync    bool          // Deliver the block before Seal returns
+	calls   int32         // Number of Seal calls, accessed atomically
+}
+
+func (e *testSealer) SealHash(header *types.Header) common.Hash { return header.Hash() }
+
+func (e *testSealer) Seal(chain ChainHeaderReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	atomic.AddInt32(&e.calls, 1)
+	if e.err != nil {
+		return e.err
+	}
+	if e.sync {
+		select {
+		case results <- block:
+		default:
+		}
+		return nil
+	}
+	release := e.release
+	go func() {
+		if release != nil {
+			select {
+			case <-release:
+			case <-stop:
+				return
+			}
+		}
+		select {
+		case results <- block:
+		default:
+		}
+	}()
+	return nil
+}
+
+func (e *testSealer) Close() error { return nil }
+
+// testBlock creates an empty block at the given height, with the timestamp used
+// to tell apart conflicting blocks at the same height.
+func testBlock(number uint64, time uint64) *types.Block {
+	return types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(number), Time: time})
+}
+
+// sealBlock seals a block with the engine and waits for the result.
+func sealBlock(engine Engine, block *types.Block) error {
+	results := make(chan *types.Block, 1)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	if err := engine.Seal(nil, block, results, stop); err != nil {
+		return err
+	}
+	select {
+	case sealed := <-results:
+		if sealed.Hash() != block.Hash() {
+			return errors.New("sealed block mismatch")
+		}
+		return nil
+	case <-time.After(time.Second):
+		return errors.New("sealing timed out")
+	}
+}
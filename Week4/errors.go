@@ -0,0 +1,45 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import "errors"
+
+var (
+	// ErrInvalidMessageSignature is returned when the signature on a consensus
+	// message cannot be recovered to a signer address.
+	ErrInvalidMessageSignature = errors.New("invalid consensus message signature")
+
+	// ErrReplayedMessage is returned when a consensus message has already been
+	// seen and is delivered again.
+	ErrReplayedMessage = errors.New("replayed consensus message")
+
+	// ErrStaleMessage is returned when a consensus message refers to a sequence
+	// that has already been finalised.
+	ErrStaleMessage = errors.New("stale consensus message")
+
+	// ErrUnknownTopic is returned when a consensus message carries a topic that
+	// is not one of the known vote topics.
+	ErrUnknownTopic = errors.New("unknown consensus message topic")
+
+	// ErrInvalidSignatureLength is returned when a signature is not exactly
+	// 65 bytes of [R || S || V].
+	ErrInvalidSignatureLength = errors.New("invalid signature length")
+
+	// ErrNonCanonicalSignature is returned when a signature has R or S outside
+	// the curve order, a high S value, or a recovery ID other than 0 or 1.
+	ErrNonCanonicalSignature = errors.New("non-canonical signature")
+
+	// ErrEmptyBlock is returned by engines configured to skip empty blocks when
+	// asked to seal a block without transactions.
+	ErrEmptyBlock = errors.New("sealing paused while waiting for transactions")
+
+	// ErrDoubleSign is returned when asked to seal a block at a height where a
+	// different block has already been sealed locally.
+	ErrDoubleSign = errors.New("conflicting block already sealed at this height")
+
+	// ErrBelowJournal is returned when asked to seal a block below the heights
+	// still covered by the seal journal, where a conflict can't be ruled out.
+	ErrBelowJournal = errors.New("block below seal journal retention window")
+)
@@ -0,0 +1,280 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errJournalClosed is returned when the journal has been closed, or could not
+// be reopened after being rewritten, so no further blocks can be sealed.
+var errJournalClosed = errors.New("seal journal closed")
+
+// journalEntry is a single record of a locally sealed block.
+type journalEntry struct {
+	Number   uint64      `json:"number"`
+	SealHash common.Hash `json:"sealHash"`
+}
+
+// guardedEngine wraps a consensus engine and records every block it seals,
+// refusing to seal a conflicting block at an already sealed height.
+type guardedEngine struct {
+	Engine
+
+	path   string                 // Location of the journal on disk
+	retain uint64                 // Number of heights below the head to keep, zero for all
+	file   *os.File               // Append-only journal of sealed blocks
+	lines  int                    // Number of entries in the journal file
+	head   uint64                 // Highest sealed block number
+	sealed map[uint64]common.Hash // Seal hashes of sealed blocks by number
+	closed bool                   // Whether Close has been called
+	lock   sync.Mutex
+}
+
+// GuardDoubleSign wraps engine with a sealed block journal stored at path. The
+// journal is replayed on startup, so a restarted signer will not seal a second,
+// different block at a height it already signed. Blocks are compared by their
+// SealHash, so re-sealing the same block is allowed.
+//
+// A height is only journaled once the wrapped engine produces a sealed block,
+// and before that block is handed to the caller. Seals that fail or are
+// stopped early leave the height free. Entries more than retain heights below
+// the highest sealed block are pruned, and blocks at those heights are refused
+// with ErrBelowJournal; a zero retain keeps every entry.
+func GuardDoubleSign(engine Engine, path string, retain uint64) (Engine, error) {
+	e := &guardedEngine{
+		Engine: engine,
+		path:   path,
+		retain: retain,
+		sealed: make(map[uint64]common.Hash),
+	}
+	if err := e.load(); err != nil {
+		return nil, err
+	}
+	if err := e.compact(); err != nil {
+		return nil, err
+	}
+	log.Info("Loaded seal journal", "path", path, "blocks", len(e.sealed))
+	return e, nil
+}
+
+// load replays the journal file into memory. A final line without a trailing
+// newline is the remainder of a write interrupted by a crash and is dropped;
+// corrupt entries anywhere else are an error.
+func (e *guardedEngine) load() error {
+	blob, err := os.ReadFile(e.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for line := 1; len(blob) > 0; line++ {
+		end := bytes.IndexByte(blob, '\n')
+		if end < 0 {
+			log.Warn("Dropping torn seal journal entry", "path", e.path, "line", line)
+			break
+		}
+		var entry journalEntry
+		if err := json.Unmarshal(blob[:end], &entry); err != nil {
+			return fmt.Errorf("invalid seal journal entry %d: %v", line, err)
+		}
+		e.sealed[entry.Number] = entry.SealHash
+		if entry.Number > e.head {
+			e.head = entry.Number
+		}
+		blob = blob[end+1:]
+	}
+	return nil
+}
+
+// floor returns the lowest block number still covered by the journal.
+func (e *guardedEngine) floor() uint64 {
+	if e.retain == 0 || e.head < e.retain {
+		return 0
+	}
+	return e.head - e.retain
+}
+
+// check returns an error if sealing the given block would conflict with the
+// journal, or if it can't be journaled at all. The caller must hold the lock.
+func (e *guardedEngine) check(number uint64, hash common.Hash) error {
+	if e.file == nil {
+		return errJournalClosed
+	}
+	if number < e.floor() {
+		return ErrBelowJournal
+	}
+	if prev, ok := e.sealed[number]; ok && prev != hash {
+		log.Error("Refusing to double sign", "number", number, "sealed", prev, "requested", hash)
+		return ErrDoubleSign
+	}
+	return nil
+}
+
+// Seal implements consensus.Engine, journaling every block the wrapped engine
+// seals before handing it to the caller.
+func (e *guardedEngine) Seal(chain ChainHeaderReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	e.lock.Lock()
+	err := e.check(block.NumberU64(), e.SealHash(block.Header()))
+	e.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	sealed := newSealResults(results)
+	if err := e.Engine.Seal(chain, block, sealed, stop); err != nil {
+		return err
+	}
+	go relaySeals(sealed, stop, func(block *types.Block) {
+		if err := e.commit(block.NumberU64(), e.SealHash(block.Header())); err != nil {
+			log.Error("Dropping sealed block", "number", block.Number(), "hash", block.Hash(), "err", err)
+			return
+		}
+		deliverSeal(results, block)
+	})
+	return nil
+}
+
+// commit journals a sealed block, failing if it conflicts with one sealed
+// concurrently at the same height.
+func (e *guardedEngine) commit(number uint64, hash common.Hash) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if err := e.check(number, hash); err != nil {
+		return err
+	}
+	if _, ok := e.sealed[number]; ok {
+		return nil
+	}
+	if err := e.record(journalEntry{Number: number, SealHash: hash}); err != nil {
+		return err
+	}
+	e.sealed[number] = hash
+	if number > e.head {
+		e.head = number
+	}
+	// Rewrite the journal once pruned entries make up half of it. The new entry
+	// is already on disk, so a failure here doesn't hold back the block.
+	if e.retain > 0 && uint64(e.lines) > 2*(e.retain+1) {
+		if err := e.compact(); err != nil {
+			log.Warn("Failed to compact seal journal", "path", e.path, "err", err)
+		}
+	}
+	return nil
+}
+
+// record appends an entry to the journal and flushes it to disk. The caller
+// must hold the lock.
+func (e *guardedEngine) record(entry journalEntry) error {
+	if e.file == nil {
+		return errJournalClosed
+	}
+	blob, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err = e.file.Write(append(blob, '\n')); err == nil {
+		err = e.file.Sync()
+	}
+	if err != nil {
+		// A failed write may have left part of the entry behind, and the next
+		// append would bury it mid-file. Rewrite the journal from memory, which
+		// doesn't hold the entry yet.
+		e.file.Close()
+		e.file = nil
+		if err := e.compact(); err != nil {
+			log.Error("Failed to repair seal journal", "path", e.path, "err", err)
+		}
+		return err
+	}
+	e.lines++
+	return nil
+}
+
+// compact drops entries below the retention floor and atomically rewrites the
+// journal with the remaining ones, reopening it for appending. The caller must
+// hold the lock.
+func (e *guardedEngine) compact() error {
+	floor := e.floor()
+	numbers := make([]uint64, 0, len(e.sealed))
+	for number := range e.sealed {
+		if number < floor {
+			delete(e.sealed, number)
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	var buf bytes.Buffer
+	for _, number := range numbers {
+		blob, err := json.Marshal(journalEntry{Number: number, SealHash: e.sealed[number]})
+		if err != nil {
+			return err
+		}
+		buf.Write(append(blob, '\n'))
+	}
+	tmp := e.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, e.path); err != nil {
+		return err
+	}
+	if e.file != nil {
+		e.file.Close()
+	}
+	if e.file, err = os.OpenFile(e.path, os.O_WRONLY|os.O_APPEND, 0600); err != nil {
+		e.file = nil
+		return err
+	}
+	e.lines = len(numbers)
+	return nil
+}
+
+// Close implements consensus.Engine, closing the journal along with the wrapped
+// engine. Blocks can't be sealed afterwards.
+func (e *guardedEngine) Close() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+
+	var err error
+	if e.file != nil {
+		err = e.file.Close()
+		e.file = nil
+	}
+	if cerr := e.Engine.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
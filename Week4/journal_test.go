@@ -0,0 +1,235 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that a height is not locked when the wrapped engine fails to seal.
+func TestSealJournalFailedSeal(t *testing.T) {
+	inner := &testSealer{err: ErrEmptyBlock}
+	engine, err := GuardDoubleSign(inner, filepath.Join(t.TempDir(), "seals"), 0)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	defer engine.Close()
+
+	if err := sealBlock(engine, testBlock(1, 1)); err != ErrEmptyBlock {
+		t.Fatalf("seal error mismatch: have %v, want %v", err, ErrEmptyBlock)
+	}
+	inner.err = nil
+	if err := sealBlock(engine, testBlock(1, 2)); err != nil {
+		t.Fatalf("failed to seal after failed attempt: %v", err)
+	}
+}
+
+// Tests that a height is not locked when sealing is stopped before the wrapped
+// engine produces a block.
+func TestSealJournalAbortedSeal(t *testing.T) {
+	inner := &testSealer{release: make(chan struct{})}
+	engine, err := GuardDoubleSign(inner, filepath.Join(t.TempDir(), "seals"), 0)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	defer engine.Close()
+
+	results := make(chan *types.Block, 1)
+	stop := make(chan struct{})
+	if err := engine.Seal(nil, testBlock(1, 1), results, stop); err != nil {
+		t.Fatalf("failed to start sealing: %v", err)
+	}
+	close(stop)
+
+	inner.release = nil
+	if err := sealBlock(engine, testBlock(1, 2)); err != nil {
+		t.Fatalf("failed to seal after aborted attempt: %v", err)
+	}
+	select {
+	case block := <-results:
+		t.Fatalf("aborted seal delivered block %v", block.Hash())
+	default:
+	}
+}
+
+// Tests that a conflicting block is refused at a sealed height, also after the
+// journal is reopened, while re-sealing the same block is allowed.
+func TestSealJournalConflict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seals")
+
+	engine, err := GuardDoubleSign(&testSealer{}, path, 0)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	if err := sealBlock(engine, testBlock(1, 1)); err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	if err := sealBlock(engine, testBlock(1, 2)); err != ErrDoubleSign {
+		t.Fatalf("conflict error mismatch: have %v, want %v", err, ErrDoubleSign)
+	}
+	if err := sealBlock(engine, testBlock(1, 1)); err != nil {
+		t.Fatalf("failed to re-seal block: %v", err)
+	}
+	engine.Close()
+
+	engine, err = GuardDoubleSign(&testSealer{}, path, 0)
+	if err != nil {
+		t.Fatalf("failed to reopen journal: %v", err)
+	}
+	defer engine.Close()
+
+	if err := sealBlock(engine, testBlock(1, 2)); err != ErrDoubleSign {
+		t.Fatalf("conflict error mismatch after reopen: have %v, want %v", err, ErrDoubleSign)
+	}
+}
+
+// Tests that an unterminated last entry left by a crash is dropped, while
+// corruption in the middle of the journal is still reported.
+func TestSealJournalTornEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seals")
+
+	engine, err := GuardDoubleSign(&testSealer{}, path, 0)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	if err := sealBlock(engine, testBlock(1, 1)); err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	engine.Close()
+
+	valid, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if err := os.WriteFile(path, append(valid, `{"number":2,"sealH`...), 0600); err != nil {
+		t.Fatalf("failed to tear journal: %v", err)
+	}
+	engine, err = GuardDoubleSign(&testSealer{}, path, 0)
+	if err != nil {
+		t.Fatalf("failed to open torn journal: %v", err)
+	}
+	if err := sealBlock(engine, testBlock(1, 2)); err != ErrDoubleSign {
+		t.Fatalf("conflict error mismatch: have %v, want %v", err, ErrDoubleSign)
+	}
+	if err := sealBlock(engine, testBlock(2, 1)); err != nil {
+		t.Fatalf("failed to seal at torn height: %v", err)
+	}
+	engine.Close()
+
+	if err := os.WriteFile(path, append([]byte("{\"number\":2,\n"), valid...), 0600); err != nil {
+		t.Fatalf("failed to corrupt journal: %v", err)
+	}
+	if _, err := GuardDoubleSign(&testSealer{}, path, 0); err == nil {
+		t.Fatalf("opened journal with corrupt entry")
+	}
+}
+
+// Tests that a failed journal write doesn't leave a partial entry behind for
+// the next append to bury mid-file, which would make the journal unloadable.
+func TestSealJournalWriteFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seals")
+
+	engine, err := GuardDoubleSign(&testSealer{}, path, 0)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	if err := sealBlock(engine, testBlock(1, 1)); err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	// Simulate a short write: leave a fragment on disk and swap in a read-only
+	// handle so the next append fails
+	guard := engine.(*guardedEngine)
+	guard.lock.Lock()
+	if _, err := guard.file.Write([]byte(`{"number":2,"se`)); err != nil {
+		t.Fatalf("failed to write fragment: %v", err)
+	}
+	guard.file.Close()
+	if guard.file, err = os.Open(path); err != nil {
+		t.Fatalf("failed to reopen journal read-only: %v", err)
+	}
+	guard.lock.Unlock()
+
+	if err := sealBlock(engine, testBlock(2, 1)); err == nil {
+		t.Fatalf("delivered block that failed to journal")
+	}
+	if err := sealBlock(engine, testBlock(3, 1)); err != nil {
+		t.Fatalf("failed to seal after journal write failure: %v", err)
+	}
+	engine.Close()
+
+	engine, err = GuardDoubleSign(&testSealer{}, path, 0)
+	if err != nil {
+		t.Fatalf("failed to reopen journal: %v", err)
+	}
+	defer engine.Close()
+
+	if err := sealBlock(engine, testBlock(2, 2)); err != nil {
+		t.Fatalf("failed to seal at unjournaled height: %v", err)
+	}
+	for _, number := range []uint64{1, 3} {
+		if err := sealBlock(engine, testBlock(number, 2)); err != ErrDoubleSign {
+			t.Errorf("block %d: conflict error mismatch: have %v, want %v", number, err, ErrDoubleSign)
+		}
+	}
+}
+
+// Tests that closing the journal twice is harmless, and that a closed journal
+// refuses to seal before the wrapped engine signs anything.
+func TestSealJournalClose(t *testing.T) {
+	inner := new(testSealer)
+	engine, err := GuardDoubleSign(inner, filepath.Join(t.TempDir(), "seals"), 0)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	if err := engine.Close(); err != nil {
+		t.Fatalf("failed to close journal: %v", err)
+	}
+	if err := engine.Close(); err != nil {
+		t.Fatalf("failed to close journal twice: %v", err)
+	}
+	if err := sealBlock(engine, testBlock(1, 1)); err != errJournalClosed {
+		t.Fatalf("seal error mismatch: have %v, want %v", err, errJournalClosed)
+	}
+	if calls := atomic.LoadInt32(&inner.calls); calls != 0 {
+		t.Fatalf("wrapped engine sealed %d times after close", calls)
+	}
+}
+
+// Tests that entries below the retention window are pruned from the journal
+// and blocks at those heights are refused.
+func TestSealJournalPruning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seals")
+
+	engine, err := GuardDoubleSign(&testSealer{}, path, 2)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	defer engine.Close()
+
+	for i := uint64(1); i <= 20; i++ {
+		if err := sealBlock(engine, testBlock(i, 1)); err != nil {
+			t.Fatalf("failed to seal block %d: %v", i, err)
+		}
+	}
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if lines := bytes.Count(blob, []byte("\n")); lines > 6 {
+		t.Errorf("journal not compacted: have %d entries, want at most 6", lines)
+	}
+	if err := sealBlock(engine, testBlock(10, 2)); err != ErrBelowJournal {
+		t.Errorf("pruned height error mismatch: have %v, want %v", err, ErrBelowJournal)
+	}
+	if err := sealBlock(engine, testBlock(19, 2)); err != ErrDoubleSign {
+		t.Errorf("retained height error mismatch: have %v, want %v", err, ErrDoubleSign)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Tests that a metered engine forwards sealed blocks to the caller, including
+// ones the wrapped engine delivers before its Seal returns.
+func TestMeteredSealDelivered(t *testing.T) {
+	for _, inner := range []*testSealer{{}, {sync: true}} {
+		engine := Metered(inner, "delivered")
+		if err := sealBlock(engine, testBlock(1, 1)); err != nil {
+			t.Errorf("sync %v: failed to seal block: %v", inner.sync, err)
+		}
+	}
+}
+
+// Tests that the seal timer records every sealed block.
+func TestMeteredSealTimer(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	var (
+		engine = Metered(new(testSealer), "timed")
+		timer  = metrics.GetOrRegisterTimer("consensus/timed/seal", nil)
+		before = timer.Count()
+	)
+	if err := sealBlock(engine, testBlock(1, 1)); err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	if count := timer.Count(); count != before+1 {
+		t.Fatalf("seal timer count mismatch: have %d, want %d", count, before+1)
+	}
+}
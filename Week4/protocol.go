@@ -0,0 +1,149 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Topic identifies the kind of vote carried by a consensus message.
+type Topic uint8
+
+const (
+	TopicPrepare     Topic = iota // Prepare votes for a proposed block
+	TopicCommit                   // Commit votes for a prepared block
+	TopicRoundChange              // Requests to move to the next round
+)
+
+// messageDomain separates consensus message signatures from anything else a
+// validator key signs.
+const messageDomain = "consensus-message"
+
+// Message is a signed consensus-layer message exchanged between validators of
+// a BFT engine.
+type Message struct {
+	Topic     Topic
+	Sequence  uint64 // Block number the vote refers to
+	Round     uint64 // Round within the sequence
+	Payload   []byte // Engine specific vote content
+	Signature []byte // Signature over SigHash by the sender
+}
+
+// SigHash returns the hash the sender signs, covering every field except the
+// signature itself. The hash is bound to a domain tag and the chain ID, so a
+// validator reusing its key on another network can't have its votes replayed
+// there.
+func (m *Message) SigHash(chainID *big.Int) common.Hash {
+	enc, err := rlp.EncodeToBytes([]interface{}{messageDomain, chainID, uint8(m.Topic), m.Sequence, m.Round, m.Payload})
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+	return crypto.Keccak256Hash(enc)
+}
+
+// Sender recovers the address of the validator that signed the message on the
+// given chain.
+func (m *Message) Sender(chainID *big.Int) (common.Address, error) {
+	if m.Topic > TopicRoundChange {
+		return common.Address{}, ErrUnknownTopic
+	}
+	if err := ValidateSignature(m.Signature); err != nil {
+		return common.Address{}, err
+	}
+	hash := m.SigHash(chainID)
+	pubkey, err := crypto.SigToPub(hash[:], m.Signature)
+	if err != nil {
+		return common.Address{}, ErrInvalidMessageSignature
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
+
+// Transport is the interface the networking layer exposes to BFT engines so
+// they can exchange votes without managing their own connections.
+type Transport interface {
+	// Broadcast sends the message to all connected validators.
+	Broadcast(msg *Message) error
+
+	// Subscribe delivers every verified, non-replayed message of the given
+	// topic into the channel.
+	Subscribe(topic Topic, ch chan<- *Message) event.Subscription
+}
+
+// ReplayFilter remembers recently seen consensus messages so a transport can
+// drop duplicates before handing them to the engine. Since the seen set is a
+// bounded LRU, votes for old sequences are rejected outright once the caller
+// raises the floor with Advance; otherwise an evicted vote could be replayed.
+type ReplayFilter struct {
+	chainID *big.Int // Chain the accepted messages must be signed for
+	seen    lru.BasicLRU[common.Hash, struct{}]
+	floor   uint64 // Lowest sequence still accepted
+	lock    sync.Mutex
+}
+
+// NewReplayFilter creates a filter for messages signed on the given chain,
+// tracking up to size recent messages.
+func NewReplayFilter(chainID *big.Int, size int) *ReplayFilter {
+	return &ReplayFilter{
+		chainID: chainID,
+		seen:    lru.NewBasicLRU[common.Hash, struct{}](size),
+	}
+}
+
+// Advance rejects all future messages with a sequence below the given one. BFT
+// engines should call it whenever a sequence is finalised.
+func (f *ReplayFilter) Advance(sequence uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if sequence > f.floor {
+		f.floor = sequence
+	}
+}
+
+// Check verifies the message signature and marks it as seen, returning the
+// signer. Messages below the sequence floor are rejected with ErrStaleMessage,
+// and messages delivered a second time with ErrReplayedMessage.
+func (f *ReplayFilter) Check(msg *Message) (common.Address, error) {
+	// Drop votes for finalised sequences before paying for signer recovery
+	if f.stale(msg.Sequence) {
+		return common.Address{}, ErrStaleMessage
+	}
+	signer, err := msg.Sender(f.chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	// Key on the signer rather than the signature bytes, so re-signing the same
+	// vote doesn't get it past the filter, while the same vote by two
+	// validators isn't mistaken for a replay.
+	key := crypto.Keccak256Hash(signer.Bytes(), msg.SigHash(f.chainID).Bytes())
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// The floor may have moved while the signer was being recovered
+	if msg.Sequence < f.floor {
+		return common.Address{}, ErrStaleMessage
+	}
+	if f.seen.Contains(key) {
+		return common.Address{}, ErrReplayedMessage
+	}
+	f.seen.Add(key, struct{}{})
+	return signer, nil
+}
+
+// stale reports whether the sequence is below the floor.
+func (f *ReplayFilter) stale(sequence uint64) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return sequence < f.floor
+}
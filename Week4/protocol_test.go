@@ -0,0 +1,155 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var testChainID = big.NewInt(1337)
+
+// newTestKey generates a validator key, failing the test on error.
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+// signedMessage creates a vote signed by key on the test chain.
+func signedMessage(t *testing.T, key *ecdsa.PrivateKey, topic Topic, sequence uint64) *Message {
+	msg := &Message{Topic: topic, Sequence: sequence, Round: 0, Payload: []byte{0x01, 0x02}}
+	sig, err := crypto.Sign(msg.SigHash(testChainID).Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to sign message: %v", err)
+	}
+	msg.Signature = sig
+	return msg
+}
+
+// signWithNonce signs hash with key using the given ECDSA nonce, producing a
+// valid low-S signature that differs from the deterministic one crypto.Sign
+// returns.
+func signWithNonce(hash []byte, key *ecdsa.PrivateKey, k *big.Int) []byte {
+	var (
+		curve  = crypto.S256()
+		n      = curve.Params().N
+		rx, ry = curve.ScalarBaseMult(k.Bytes())
+	)
+	r := new(big.Int).Mod(rx, n)
+	s := new(big.Int).Mul(r, key.D)
+	s.Add(s, new(big.Int).SetBytes(hash))
+	s.Mul(s, new(big.Int).ModInverse(k, n))
+	s.Mod(s, n)
+
+	v := byte(ry.Bit(0))
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+		v ^= 1
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[crypto.RecoveryIDOffset] = v
+	return sig
+}
+
+// Tests that a message delivered twice is rejected the second time.
+func TestReplayFilterDuplicate(t *testing.T) {
+	var (
+		key    = newTestKey(t)
+		msg    = signedMessage(t, key, TopicPrepare, 1)
+		filter = NewReplayFilter(testChainID, 16)
+	)
+	signer, err := filter.Check(msg)
+	if err != nil {
+		t.Fatalf("failed to accept message: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey); signer != want {
+		t.Fatalf("signer mismatch: have %x, want %x", signer, want)
+	}
+	if _, err := filter.Check(msg); err != ErrReplayedMessage {
+		t.Fatalf("replay error mismatch: have %v, want %v", err, ErrReplayedMessage)
+	}
+}
+
+// Tests that the same vote cast by two validators is accepted from both.
+func TestReplayFilterDistinctSigners(t *testing.T) {
+	filter := NewReplayFilter(testChainID, 16)
+
+	if _, err := filter.Check(signedMessage(t, newTestKey(t), TopicCommit, 1)); err != nil {
+		t.Fatalf("failed to accept first vote: %v", err)
+	}
+	if _, err := filter.Check(signedMessage(t, newTestKey(t), TopicCommit, 1)); err != nil {
+		t.Fatalf("failed to accept second validator's vote: %v", err)
+	}
+}
+
+// Tests that re-signing an already seen vote with the same key doesn't get it
+// past the filter.
+func TestReplayFilterResigned(t *testing.T) {
+	var (
+		key    = newTestKey(t)
+		msg    = signedMessage(t, key, TopicPrepare, 1)
+		filter = NewReplayFilter(testChainID, 16)
+	)
+	if _, err := filter.Check(msg); err != nil {
+		t.Fatalf("failed to accept message: %v", err)
+	}
+	resigned := *msg
+	resigned.Signature = signWithNonce(msg.SigHash(testChainID).Bytes(), key, big.NewInt(0x1234567))
+	if bytes.Equal(resigned.Signature, msg.Signature) {
+		t.Fatalf("re-signed message has identical signature")
+	}
+	if _, err := filter.Check(&resigned); err != ErrReplayedMessage {
+		t.Fatalf("replay error mismatch: have %v, want %v", err, ErrReplayedMessage)
+	}
+}
+
+// Tests that messages below the floor set by Advance are rejected, while those
+// at or above it are still accepted.
+func TestReplayFilterStale(t *testing.T) {
+	var (
+		key    = newTestKey(t)
+		filter = NewReplayFilter(testChainID, 16)
+	)
+	filter.Advance(5)
+	filter.Advance(3) // Lowering the floor is ignored
+
+	if _, err := filter.Check(signedMessage(t, key, TopicCommit, 4)); err != ErrStaleMessage {
+		t.Fatalf("stale error mismatch: have %v, want %v", err, ErrStaleMessage)
+	}
+	if _, err := filter.Check(signedMessage(t, key, TopicCommit, 5)); err != nil {
+		t.Fatalf("failed to accept message at floor: %v", err)
+	}
+}
+
+// Tests that messages with an unknown topic are rejected.
+func TestReplayFilterUnknownTopic(t *testing.T) {
+	filter := NewReplayFilter(testChainID, 16)
+
+	if _, err := filter.Check(signedMessage(t, newTestKey(t), TopicRoundChange+1, 1)); err != ErrUnknownTopic {
+		t.Fatalf("topic error mismatch: have %v, want %v", err, ErrUnknownTopic)
+	}
+}
+
+// Tests that a vote signed for one chain doesn't recover to its signer on
+// another.
+func TestMessageChainBinding(t *testing.T) {
+	var (
+		key = newTestKey(t)
+		msg = signedMessage(t, key, TopicRoundChange, 1)
+	)
+	signer, err := msg.Sender(big.NewInt(1))
+	if err == nil && signer == crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("vote recovered to its signer on a different chain")
+	}
+}
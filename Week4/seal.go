@@ -0,0 +1,52 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// newSealResults creates the channel an engine wrapper hands to the wrapped
+// engine's Seal in place of the caller's results channel. It is buffered at
+// least as deep as the caller's, since engines deliver with a non-blocking
+// send and would otherwise drop blocks the wrapper isn't ready to receive.
+func newSealResults(results chan<- *types.Block) chan *types.Block {
+	size := cap(results)
+	if size < 1 {
+		size = 1
+	}
+	return make(chan *types.Block, size)
+}
+
+// relaySeals calls fn for every block delivered on sealed until stop is
+// closed, including blocks already buffered when it is.
+func relaySeals(sealed <-chan *types.Block, stop <-chan struct{}, fn func(*types.Block)) {
+	for {
+		select {
+		case block := <-sealed:
+			fn(block)
+		case <-stop:
+			for {
+				select {
+				case block := <-sealed:
+					fn(block)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliverSeal forwards a sealed block to the caller the same way engines do,
+// without blocking if the result is not being read.
+func deliverSeal(results chan<- *types.Block, block *types.Block) {
+	select {
+	case results <- block:
+	default:
+		log.Warn("Sealing result is not read by miner", "number", block.Number(), "hash", block.Hash())
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// shadowEngine wraps a consensus engine, performing all sealing work but only
+// logging the sealed blocks instead of delivering them to the caller.
+type shadowEngine struct {
+	Engine
+
+	sealedHook func(*types.Block) // Method to call upon each shadow sealed block (testing only)
+}
+
+// Shadow wraps engine for rehearsal runs: blocks are sealed and signed as
+// usual, but never reach the results channel, so nothing is published and the
+// validator cannot double sign against its production counterpart.
+func Shadow(engine Engine) Engine {
+	return &shadowEngine{Engine: engine}
+}
+
+// Seal implements consensus.Engine, sealing the block with the wrapped engine
+// and logging every result until stop is closed.
+func (e *shadowEngine) Seal(chain ChainHeaderReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	sealed := newSealResults(results)
+	if err := e.Engine.Seal(chain, block, sealed, stop); err != nil {
+		return err
+	}
+	go relaySeals(sealed, stop, func(block *types.Block) {
+		log.Info("Shadow sealed block", "number", block.Number(), "hash", block.Hash())
+		if e.sealedHook != nil {
+			e.sealedHook(block)
+		}
+	})
+	return nil
+}
+
+// shadowTransport wraps a consensus message transport, receiving votes as
+// usual but only logging the ones the local validator would send.
+type shadowTransport struct {
+	Transport
+}
+
+// ShadowTransport wraps transport so that outgoing votes are logged instead of
+// broadcast. Subscriptions are passed through unchanged.
+func ShadowTransport(transport Transport) Transport {
+	return &shadowTransport{Transport: transport}
+}
+
+// Broadcast implements consensus.Transport, logging the message instead of
+// sending it.
+func (t *shadowTransport) Broadcast(msg *Message) error {
+	log.Info("Shadow signed consensus message", "topic", msg.Topic, "sequence", msg.Sequence, "round", msg.Round)
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// shadowSeal seals a block through a shadow engine wrapping inner, closing stop
+// before or after the call, and returns the block the shadow engine received
+// along with the caller's results channel.
+func shadowSeal(t *testing.T, inner Engine, stopFirst bool) (*types.Block, chan *types.Block) {
+	var (
+		engine  = Shadow(inner).(*shadowEngine)
+		shadow  = make(chan *types.Block, 1)
+		results = make(chan *types.Block, 1)
+		stop    = make(chan struct{})
+		block   = testBlock(1, 1)
+	)
+	engine.sealedHook = func(block *types.Block) { shadow <- block }
+
+	if stopFirst {
+		close(stop)
+	} else {
+		defer close(stop)
+	}
+	if err := engine.Seal(nil, block, results, stop); err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	select {
+	case sealed := <-shadow:
+		if sealed.Hash() != block.Hash() {
+			t.Fatalf("shadow sealed block mismatch: have %v, want %v", sealed.Hash(), block.Hash())
+		}
+		return sealed, results
+	case <-time.After(time.Second):
+		t.Fatalf("shadow engine never received sealed block")
+	}
+	return nil, nil
+}
+
+// Tests that the shadow engine receives the sealed block but never hands it to
+// the caller.
+func TestShadowSealNotDelivered(t *testing.T) {
+	_, results := shadowSeal(t, new(testSealer), false)
+
+	select {
+	case block := <-results:
+		t.Fatalf("shadow engine delivered block %v", block.Hash())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// Tests that a block delivered before the wrapped engine's Seal returns is not
+// lost.
+func TestShadowSealSynchronous(t *testing.T) {
+	shadowSeal(t, &testSealer{sync: true}, false)
+}
+
+// Tests that a block already buffered when stop closes is still received.
+func TestShadowSealDrainOnStop(t *testing.T) {
+	shadowSeal(t, &testSealer{sync: true}, true)
+}
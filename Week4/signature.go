@@ -0,0 +1,27 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ValidateSignature checks that a [R || S || V] signature is in canonical
+// form: R and S lie within the secp256k1 order, S is in the lower half of it
+// and the recovery ID is 0 or 1. Engines should call it before recovering a
+// sealer so that no two engines disagree about a malleated signature.
+func ValidateSignature(sig []byte) error {
+	if len(sig) != crypto.SignatureLength {
+		return ErrInvalidSignatureLength
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if !crypto.ValidateSignatureValues(sig[crypto.RecoveryIDOffset], r, s, true) {
+		return ErrNonCanonicalSignature
+	}
+	return nil
+}
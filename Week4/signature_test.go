@@ -0,0 +1,57 @@
+// Copyright 2026 The Blockchain Authors
+// This file is distributed under the GNU Lesser General Public License, like
+// the go-ethereum consensus package it extends.
+
+package consensus
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests that only canonical 65 byte signatures pass validation.
+func TestValidateSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	valid, err := crypto.Sign(crypto.Keccak256([]byte("vote")), key)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	n := crypto.S256().Params().N
+
+	// modify returns a copy of the valid signature changed by fn
+	modify := func(fn func(sig []byte)) []byte {
+		sig := append([]byte{}, valid...)
+		fn(sig)
+		return sig
+	}
+	tests := []struct {
+		name string
+		sig  []byte
+		err  error
+	}{
+		{"valid low S", valid, nil},
+		{"high S", modify(func(sig []byte) {
+			s := new(big.Int).SetBytes(sig[32:64])
+			new(big.Int).Sub(n, s).FillBytes(sig[32:64])
+			sig[64] ^= 1
+		}), ErrNonCanonicalSignature},
+		{"zero R", modify(func(sig []byte) { copy(sig[:32], make([]byte, 32)) }), ErrNonCanonicalSignature},
+		{"zero S", modify(func(sig []byte) { copy(sig[32:64], make([]byte, 32)) }), ErrNonCanonicalSignature},
+		{"R equal to N", modify(func(sig []byte) { n.FillBytes(sig[:32]) }), ErrNonCanonicalSignature},
+		{"R above N", modify(func(sig []byte) { new(big.Int).Add(n, big.NewInt(1)).FillBytes(sig[:32]) }), ErrNonCanonicalSignature},
+		{"V of 2", modify(func(sig []byte) { sig[64] = 2 }), ErrNonCanonicalSignature},
+		{"V of 27", modify(func(sig []byte) { sig[64] = 27 }), ErrNonCanonicalSignature},
+		{"64 bytes", valid[:64], ErrInvalidSignatureLength},
+		{"66 bytes", append(append([]byte{}, valid...), 0), ErrInvalidSignatureLength},
+	}
+	for _, tt := range tests {
+		if err := ValidateSignature(tt.sig); err != tt.err {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}